@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import "github.com/aws/aws-sdk-go/service/ec2"
+
+// EC2 is the subset of the AWS EC2 API this package depends on.
+type EC2 interface {
+	DescribeAvailabilityZones(request *ec2.DescribeAvailabilityZonesInput) ([]*ec2.AvailabilityZone, error)
+	DescribeInstances(request *ec2.DescribeInstancesInput) ([]*ec2.Instance, error)
+	DescribeVolumes(request *ec2.DescribeVolumesInput) ([]*ec2.Volume, error)
+}
+
+// Cloud is the AWS implementation of cloudprovider.Interface.
+type Cloud struct {
+	ec2    EC2
+	region string
+
+	zoneCache       zoneCache
+	volumeZoneCache volumeZoneCache
+	instanceCache   *instanceCache
+}
+
+// newCloud returns a Cloud for the given region, backed by ec2Services.
+func newCloud(region string, ec2Services EC2) *Cloud {
+	c := &Cloud{
+		ec2:    ec2Services,
+		region: region,
+	}
+
+	c.zoneCache = zoneCache{cloud: c}
+	c.volumeZoneCache = volumeZoneCache{cloud: c}
+	c.instanceCache = newInstanceCache(c, defaultInstanceCacheTTL)
+
+	return c
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+// GetLoadBalancerSubnets is the subnet-selection call site the service
+// controller uses when provisioning an ELB/NLB/ALB for a LoadBalancer-type
+// Service. Given the candidate subnets for the service (subnet ID to the
+// AWS availability zone it's in), it returns the subset suitable as load
+// balancer targets, excluding Local Zone and Wavelength Zone subnets since
+// those zones cannot host internet-facing load balancers.
+func (c *Cloud) GetLoadBalancerSubnets(candidateSubnetZones map[string]string) (map[string]string, error) {
+	return c.filterStandardZoneSubnets(candidateSubnetZones)
+}
+
+// filterStandardZoneSubnets removes subnets located in Local Zones or
+// Wavelength Zones from subnetZones (a map of subnet ID to the zone it's
+// in). A zone whose details can't be resolved is treated as non-standard
+// and excluded, so a transient zone-cache miss fails closed rather than
+// risking a Local Zone subnet being left in as an ELB target.
+func (c *Cloud) filterStandardZoneSubnets(subnetZones map[string]string) (map[string]string, error) {
+	zoneNames := make([]string, 0, len(subnetZones))
+	for _, zoneName := range subnetZones {
+		zoneNames = append(zoneNames, zoneName)
+	}
+
+	details, err := c.zoneCache.getZoneDetailsByNames(zoneNames)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make(map[string]string, len(subnetZones))
+	for subnetID, zoneName := range subnetZones {
+		if zone, ok := details[zoneName]; ok && zone.isStandardZone() {
+			filtered[subnetID] = zoneName
+		}
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestGetLoadBalancerSubnetsExcludesNonStandardZones(t *testing.T) {
+	fake := &fakeEC2{
+		zones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a"), ZoneId: aws.String("use1-az1"), ZoneType: aws.String(ZoneTypeAvailabilityZone)},
+			{ZoneName: aws.String("us-east-1-lax-1a"), ZoneId: aws.String("use1-lax1-az1"), ZoneType: aws.String(ZoneTypeLocalZone)},
+		},
+	}
+	c := newCloud("us-east-1", fake)
+
+	candidates := map[string]string{
+		"subnet-standard":  "us-east-1a",
+		"subnet-localzone": "us-east-1-lax-1a",
+	}
+
+	got, err := c.GetLoadBalancerSubnets(candidates)
+	if err != nil {
+		t.Fatalf("GetLoadBalancerSubnets returned error: %v", err)
+	}
+
+	want := map[string]string{"subnet-standard": "us-east-1a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLoadBalancerSubnets() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterStandardZoneSubnetsFailsClosedOnZoneCacheMiss(t *testing.T) {
+	fake := &fakeEC2{
+		zones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a"), ZoneId: aws.String("use1-az1"), ZoneType: aws.String(ZoneTypeAvailabilityZone)},
+		},
+	}
+	c := newCloud("us-east-1", fake)
+
+	candidates := map[string]string{
+		"subnet-known":   "us-east-1a",
+		"subnet-unknown": "us-east-1z", // not returned by DescribeAvailabilityZones
+	}
+
+	got, err := c.filterStandardZoneSubnets(candidates)
+	if err != nil {
+		t.Fatalf("filterStandardZoneSubnets returned error: %v", err)
+	}
+
+	want := map[string]string{"subnet-known": "us-east-1a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterStandardZoneSubnets() = %v, want %v (a zone-cache miss must exclude the subnet, not include it)", got, want)
+	}
+}
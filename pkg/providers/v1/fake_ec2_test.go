@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// fakeEC2 is a test double for the EC2 interface. It serves canned
+// responses and counts calls per method so tests can assert on batching and
+// coalescing behavior.
+type fakeEC2 struct {
+	mutex sync.Mutex
+
+	zones     []*ec2.AvailabilityZone
+	instances []*ec2.Instance
+	volumes   []*ec2.Volume
+
+	describeAvailabilityZonesCalls int
+	describeInstancesCalls         int
+	describeVolumesCalls           int
+}
+
+func (f *fakeEC2) DescribeAvailabilityZones(request *ec2.DescribeAvailabilityZonesInput) ([]*ec2.AvailabilityZone, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.describeAvailabilityZonesCalls++
+
+	region := ""
+	for _, filter := range request.Filters {
+		if filter.Name != nil && *filter.Name == "region-name" && len(filter.Values) > 0 {
+			region = *filter.Values[0]
+		}
+	}
+	if region == "" {
+		return f.zones, nil
+	}
+
+	var matched []*ec2.AvailabilityZone
+	for _, zone := range f.zones {
+		if zone.ZoneName != nil && zoneRegion(*zone.ZoneName) == region {
+			matched = append(matched, zone)
+		}
+	}
+	return matched, nil
+}
+
+// zoneRegion derives the region a zone name belongs to by stripping its
+// trailing availability-zone letter (e.g. "us-east-1" for "us-east-1a").
+func zoneRegion(zoneName string) string {
+	i := len(zoneName)
+	for i > 0 && zoneName[i-1] >= 'a' && zoneName[i-1] <= 'z' {
+		i--
+	}
+	return zoneName[:i]
+}
+
+func (f *fakeEC2) DescribeInstances(request *ec2.DescribeInstancesInput) ([]*ec2.Instance, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.describeInstancesCalls++
+
+	if len(request.InstanceIds) == 0 {
+		return f.instances, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, id := range request.InstanceIds {
+		wanted[*id] = true
+	}
+
+	var matched []*ec2.Instance
+	for _, instance := range f.instances {
+		if wanted[*instance.InstanceId] {
+			matched = append(matched, instance)
+		}
+	}
+	return matched, nil
+}
+
+func (f *fakeEC2) DescribeVolumes(request *ec2.DescribeVolumesInput) ([]*ec2.Volume, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.describeVolumesCalls++
+
+	wanted := map[string]bool{}
+	for _, id := range request.VolumeIds {
+		wanted[*id] = true
+	}
+
+	var matched []*ec2.Volume
+	for _, volume := range f.volumes {
+		if wanted[*volume.VolumeId] {
+			matched = append(matched, volume)
+		}
+	}
+	return matched, nil
+}
+
+func (f *fakeEC2) callCounts() (describeAZ, describeInstances, describeVolumes int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.describeAvailabilityZonesCalls, f.describeInstancesCalls, f.describeVolumesCalls
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultInstanceCacheTTL is the TTL newCloud gives the instance cache. No
+// cloud config setting overrides it.
+const defaultInstanceCacheTTL = 60 * time.Second
+
+var (
+	instanceCacheHitTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "cloudprovider_aws",
+		Name:      "instance_cache_hit_total",
+		Help:      "Number of DescribeInstances lookups served from the instance cache.",
+	})
+	instanceCacheMissTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "cloudprovider_aws",
+		Name:      "instance_cache_miss_total",
+		Help:      "Number of DescribeInstances lookups that required an EC2 API call.",
+	})
+	instanceCacheEvictionTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "cloudprovider_aws",
+		Name:      "instance_cache_eviction_total",
+		Help:      "Number of instance cache entries evicted for exceeding their TTL.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(instanceCacheHitTotal, instanceCacheMissTotal, instanceCacheEvictionTotal)
+}
+
+// instanceCacheEntry holds a cached DescribeInstances result along with the
+// time it was fetched, so entries can be expired after ttl.
+type instanceCacheEntry struct {
+	instance  *ec2.Instance
+	fetchedAt time.Time
+}
+
+// instanceCache is a timed cache of EC2 instance descriptions keyed by
+// instance ID, fronting DescribeInstances so that node, service, and CSI
+// attach controllers reconciling the same instance don't each round-trip to
+// EC2. Concurrent lookups for the same instance ID are coalesced into a
+// single API call via singleflight. A background sweep drops expired
+// entries periodically, so instance IDs for terminated instances (e.g.
+// reclaimed spot or scaled-down nodes) don't accumulate in entries forever
+// between lookups.
+type instanceCache struct {
+	cloud *Cloud
+	ttl   time.Duration
+
+	mutex   sync.RWMutex
+	entries map[string]instanceCacheEntry
+
+	group singleflight.Group
+
+	stopCh chan struct{}
+}
+
+// newInstanceCache returns an instanceCache with the given TTL, or
+// defaultInstanceCacheTTL if ttl is zero, and starts its background
+// eviction sweep.
+func newInstanceCache(cloud *Cloud, ttl time.Duration) *instanceCache {
+	if ttl == 0 {
+		ttl = defaultInstanceCacheTTL
+	}
+
+	c := &instanceCache{
+		cloud:   cloud,
+		ttl:     ttl,
+		entries: map[string]instanceCacheEntry{},
+		stopCh:  make(chan struct{}),
+	}
+
+	go c.runEvictionSweep()
+
+	return c
+}
+
+// runEvictionSweep periodically drops expired entries until stop is called.
+func (c *instanceCache) runEvictionSweep() {
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.evictExpired()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// evictExpired removes every entry older than ttl.
+func (c *instanceCache) evictExpired() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for instanceID, entry := range c.entries {
+		if time.Since(entry.fetchedAt) >= c.ttl {
+			delete(c.entries, instanceID)
+			instanceCacheEvictionTotal.Inc()
+		}
+	}
+}
+
+// stop halts the background eviction sweep. Production instanceCaches live
+// for the lifetime of the process; this exists so tests can shut the sweep
+// down cleanly.
+func (c *instanceCache) stop() {
+	close(c.stopCh)
+}
+
+// getInstanceByID returns the EC2 instance with the given instance ID,
+// preferring a DescribeInstances call scoped to that single ID over scanning
+// for it, and serving from cache when the entry hasn't expired.
+func (c *instanceCache) getInstanceByID(instanceID string) (*ec2.Instance, error) {
+	c.mutex.RLock()
+	entry, ok := c.entries[instanceID]
+	c.mutex.RUnlock()
+
+	if ok {
+		if time.Since(entry.fetchedAt) < c.ttl {
+			instanceCacheHitTotal.Inc()
+			return entry.instance, nil
+		}
+		instanceCacheEvictionTotal.Inc()
+	}
+
+	instanceCacheMissTotal.Inc()
+
+	result, err, _ := c.group.Do(instanceID, func() (interface{}, error) {
+		// Another lookup may have stored a fresh entry after this one missed
+		// the cache but before it called Do.
+		c.mutex.RLock()
+		entry, ok := c.entries[instanceID]
+		c.mutex.RUnlock()
+		if ok && time.Since(entry.fetchedAt) < c.ttl {
+			return entry.instance, nil
+		}
+
+		instance, err := c.describeInstance(instanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mutex.Lock()
+		c.entries[instanceID] = instanceCacheEntry{instance: instance, fetchedAt: time.Now()}
+		c.mutex.Unlock()
+
+		return instance, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*ec2.Instance), nil
+}
+
+// store populates the cache with an instance obtained some way other than
+// getInstanceByID, e.g. a node-name filter lookup, so that a subsequent
+// lookup of the same instance by ID is a cache hit instead of a second
+// round-trip to EC2.
+func (c *instanceCache) store(instance *ec2.Instance) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[aws.StringValue(instance.InstanceId)] = instanceCacheEntry{instance: instance, fetchedAt: time.Now()}
+}
+
+// describeInstance looks up a single instance by ID via DescribeInstances
+// with an InstanceIds filter, which is a single bounded API call rather than
+// a node-name-tag scan across all instances.
+func (c *instanceCache) describeInstance(instanceID string) (*ec2.Instance, error) {
+	request := &ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	instances, err := c.cloud.ec2.DescribeInstances(request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing instance %q: %q", instanceID, err)
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("instance %q not found", instanceID)
+	}
+
+	return instances[0], nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestInstanceCacheCoalescesConcurrentLookups(t *testing.T) {
+	fake := &fakeEC2{
+		instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-1"), InstanceType: aws.String("m5.large")},
+		},
+	}
+	cache := newInstanceCache(&Cloud{ec2: fake}, time.Minute)
+	defer cache.stop()
+
+	const concurrency = 20
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := cache.getInstanceByID("i-1"); err != nil {
+				t.Errorf("getInstanceByID returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, gotInstances, _ := fake.callCounts(); gotInstances != 1 {
+		t.Errorf("DescribeInstances called %d times, want 1", gotInstances)
+	}
+}
+
+func TestInstanceCacheServesFromCacheUntilExpiry(t *testing.T) {
+	fake := &fakeEC2{
+		instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-1"), InstanceType: aws.String("m5.large")},
+		},
+	}
+	cache := newInstanceCache(&Cloud{ec2: fake}, 20*time.Millisecond)
+	defer cache.stop()
+
+	if _, err := cache.getInstanceByID("i-1"); err != nil {
+		t.Fatalf("getInstanceByID returned error: %v", err)
+	}
+	if _, err := cache.getInstanceByID("i-1"); err != nil {
+		t.Fatalf("getInstanceByID returned error: %v", err)
+	}
+	if _, gotInstances, _ := fake.callCounts(); gotInstances != 1 {
+		t.Fatalf("DescribeInstances called %d times before expiry, want 1", gotInstances)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, err := cache.getInstanceByID("i-1"); err != nil {
+		t.Fatalf("getInstanceByID returned error: %v", err)
+	}
+	if _, gotInstances, _ := fake.callCounts(); gotInstances != 2 {
+		t.Errorf("DescribeInstances called %d times after expiry, want 2", gotInstances)
+	}
+}
+
+func TestInstanceCacheEvictionSweepDropsExpiredEntries(t *testing.T) {
+	fake := &fakeEC2{
+		instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-1"), InstanceType: aws.String("m5.large")},
+		},
+	}
+	cache := newInstanceCache(&Cloud{ec2: fake}, 10*time.Millisecond)
+	defer cache.stop()
+
+	if _, err := cache.getInstanceByID("i-1"); err != nil {
+		t.Fatalf("getInstanceByID returned error: %v", err)
+	}
+
+	// The background sweep runs on a ttl-period ticker; give it enough time
+	// to fire at least once after the entry has expired.
+	time.Sleep(40 * time.Millisecond)
+
+	cache.mutex.RLock()
+	_, stillPresent := cache.entries["i-1"]
+	cache.mutex.RUnlock()
+
+	if stillPresent {
+		t.Error("expected expired entry to be swept, but it's still in the cache")
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v1 "k8s.io/api/core/v1"
+	cloudprovider "k8s.io/cloud-provider"
+)
+
+// InstanceMetadata implements cloudprovider.InstancesV2. Alongside the
+// standard zone/region/instance-type metadata, it sets AdditionalLabels from
+// zoneTopologyLabels so the cloud node lifecycle controller labels nodes
+// with their stable zone ID and, for Local Zones and Wavelength Zones, their
+// zone type - this is the node labeling path that lets downstream consumers
+// such as load balancer target selection exclude nodes in non-standard
+// zones. GetLoadBalancerSubnets does not read these labels; it filters
+// subnets by the zone cache's zone type.
+func (c *Cloud) InstanceMetadata(ctx context.Context, node *v1.Node) (*cloudprovider.InstanceMetadata, error) {
+	instance, err := c.getInstanceByProviderID(node.Spec.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneName := aws.StringValue(instance.Placement.AvailabilityZone)
+	details, err := c.zoneCache.getZoneDetailsByNames([]string{zoneName})
+	if err != nil {
+		return nil, err
+	}
+
+	zone, ok := details[zoneName]
+	if !ok {
+		return nil, fmt.Errorf("zone %q not found for instance %q", zoneName, aws.StringValue(instance.InstanceId))
+	}
+
+	return &cloudprovider.InstanceMetadata{
+		ProviderID:       node.Spec.ProviderID,
+		InstanceType:     aws.StringValue(instance.InstanceType),
+		Zone:             zone.name,
+		Region:           c.region,
+		AdditionalLabels: zoneTopologyLabels(zone),
+	}, nil
+}
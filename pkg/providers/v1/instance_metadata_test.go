@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestInstanceMetadataSetsZoneIDLabel(t *testing.T) {
+	fake := &fakeEC2{
+		zones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a"), ZoneId: aws.String("use1-az2"), ZoneType: aws.String(ZoneTypeAvailabilityZone)},
+		},
+		instances: []*ec2.Instance{
+			{
+				InstanceId:   aws.String("i-0123456789abcdef0"),
+				InstanceType: aws.String("m5.large"),
+				Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+			},
+		},
+	}
+	c := newCloud("us-east-1", fake)
+
+	node := &v1.Node{
+		Spec: v1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123456789abcdef0"},
+	}
+
+	got, err := c.InstanceMetadata(context.Background(), node)
+	if err != nil {
+		t.Fatalf("InstanceMetadata returned error: %v", err)
+	}
+
+	want := map[string]string{LabelZoneID: "use1-az2"}
+	if !reflect.DeepEqual(got.AdditionalLabels, want) {
+		t.Errorf("AdditionalLabels = %v, want %v", got.AdditionalLabels, want)
+	}
+	if got.Zone != "us-east-1a" {
+		t.Errorf("Zone = %q, want %q", got.Zone, "us-east-1a")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// awsProviderIDPrefix is the prefix Kubernetes providerIDs use for AWS
+// instances, e.g. "aws:///us-east-1a/i-0123456789abcdef0".
+const awsProviderIDPrefix = "aws:///"
+
+// getInstanceByProviderID returns the EC2 instance referenced by a
+// Kubernetes providerID. The providerID already encodes the instance ID, so
+// this goes straight through the instance cache's bounded
+// DescribeInstances-by-ID path instead of scanning for it.
+func (c *Cloud) getInstanceByProviderID(providerID string) (*ec2.Instance, error) {
+	instanceID, err := instanceIDFromProviderID(providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.instanceCache.getInstanceByID(instanceID)
+}
+
+// getInstanceByNodeName returns the EC2 instance backing the node with the
+// given Kubernetes node name. Unlike a providerID, a node name doesn't
+// encode the instance ID, so every lookup by node name is a DescribeInstances
+// call with a private-dns-name filter; the result is stored in the instance
+// cache so a later lookup of the same instance by ID or providerID is a cache
+// hit.
+func (c *Cloud) getInstanceByNodeName(nodeName types.NodeName) (*ec2.Instance, error) {
+	request := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("private-dns-name"),
+				Values: aws.StringSlice([]string{string(nodeName)}),
+			},
+		},
+	}
+
+	instances, err := c.ec2.DescribeInstances(request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing instance %q: %q", nodeName, err)
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("instance not found for node name %q", nodeName)
+	}
+
+	instance := instances[0]
+	c.instanceCache.store(instance)
+
+	return instance, nil
+}
+
+// instanceIDFromProviderID extracts the EC2 instance ID from a Kubernetes
+// providerID of the form aws:///<az>/<instance-id>.
+func instanceIDFromProviderID(providerID string) (string, error) {
+	if !strings.HasPrefix(providerID, awsProviderIDPrefix) {
+		return "", fmt.Errorf("invalid provider ID %q, expected prefix %q", providerID, awsProviderIDPrefix)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(providerID, awsProviderIDPrefix), "/")
+	instanceID := parts[len(parts)-1]
+	if instanceID == "" {
+		return "", fmt.Errorf("invalid provider ID %q, no instance ID found", providerID)
+	}
+
+	return instanceID, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import "testing"
+
+func TestInstanceIDFromProviderID(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "valid provider ID",
+			providerID: "aws:///us-east-1a/i-0123456789abcdef0",
+			want:       "i-0123456789abcdef0",
+		},
+		{
+			name:       "missing prefix",
+			providerID: "i-0123456789abcdef0",
+			wantErr:    true,
+		},
+		{
+			name:       "empty instance ID",
+			providerID: "aws:///us-east-1a/",
+			wantErr:    true,
+		},
+		{
+			name:       "wrong cloud prefix",
+			providerID: "gce:///us-east-1a/i-0123456789abcdef0",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := instanceIDFromProviderID(tt.providerID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("instanceIDFromProviderID(%q) expected error, got %q", tt.providerID, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("instanceIDFromProviderID(%q) returned error: %v", tt.providerID, err)
+			}
+			if got != tt.want {
+				t.Errorf("instanceIDFromProviderID(%q) = %q, want %q", tt.providerID, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import "context"
+
+// RestoreSnapshotZone picks the destination availability zone to use when
+// creating an EBS volume from a snapshot that was copied from srcZone in
+// srcRegion. This is the call site the EBS CSI controller's CreateVolume
+// path uses instead of passing the source AZ straight through, which fails
+// with "invalid AZ" once a snapshot has been copied to a different region -
+// the deterministic zone-ID-suffix mapping in zoneCache.FromSourceRegionZone
+// makes that restore succeed instead.
+func (c *Cloud) RestoreSnapshotZone(ctx context.Context, srcRegion, srcZone string) (string, error) {
+	return c.zoneCache.FromSourceRegionZone(ctx, srcRegion, srcZone)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestRestoreSnapshotZone(t *testing.T) {
+	fake := &fakeEC2{
+		zones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a"), ZoneId: aws.String("use1-az2")},
+			{ZoneName: aws.String("us-west-2b"), ZoneId: aws.String("usw2-az2")},
+		},
+	}
+	c := newCloud("us-west-2", fake)
+
+	got, err := c.RestoreSnapshotZone(context.Background(), "us-east-1", "us-east-1a")
+	if err != nil {
+		t.Fatalf("RestoreSnapshotZone returned error: %v", err)
+	}
+	if want := "us-west-2b"; got != want {
+		t.Errorf("RestoreSnapshotZone() = %q, want %q", got, want)
+	}
+}
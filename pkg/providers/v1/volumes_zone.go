@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultVolumeZoneBatchWindow is how long getZoneForVolume waits for more
+// volume IDs to arrive before issuing the batched DescribeVolumes call.
+const defaultVolumeZoneBatchWindow = 10 * time.Millisecond
+
+// GetLabelsForVolume implements cloudprovider.PVLabeler. It resolves the EBS
+// volume backing pv to its availability zone and returns the topology
+// labels the PV admission plugin uses to constrain scheduling of pods using
+// the volume to that zone, so callers don't each have to re-do the EC2
+// lookup themselves.
+func (c *Cloud) GetLabelsForVolume(ctx context.Context, pv *v1.PersistentVolume) (map[string]string, error) {
+	if pv.Spec.AWSElasticBlockStore == nil {
+		return nil, nil
+	}
+
+	volumeID := pv.Spec.AWSElasticBlockStore.VolumeID
+	if volumeID == "" {
+		return nil, fmt.Errorf("PersistentVolume %s has no EBS volume ID", pv.Name)
+	}
+
+	zoneName, err := c.volumeZoneCache.getZoneForVolume(volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := c.zoneCache.getZoneDetailsByNames([]string{zoneName})
+	if err != nil {
+		return nil, err
+	}
+
+	zone, ok := details[zoneName]
+	if !ok {
+		return nil, fmt.Errorf("zone %q not found for volume %q", zoneName, volumeID)
+	}
+
+	result := map[string]string{
+		v1.LabelTopologyRegion: c.region,
+		v1.LabelTopologyZone:   zone.name,
+	}
+	for k, v := range zoneTopologyLabels(zone) {
+		result[k] = v
+	}
+
+	return result, nil
+}
+
+// volumeZoneResult is the outcome of resolving a single volume ID to its
+// availability zone.
+type volumeZoneResult struct {
+	zoneName string
+	err      error
+}
+
+// volumeZoneCache resolves EBS volume IDs to their availability zone. Rather
+// than coalescing only repeat lookups of the same volume ID, it accumulates
+// every distinct volume ID requested within a short batch window into one
+// DescribeVolumes call, which is what actually protects against EC2 rate
+// limiting when many PersistentVolumes are labeled concurrently at
+// controller startup.
+type volumeZoneCache struct {
+	cloud       *Cloud
+	batchWindow time.Duration
+
+	mutex      sync.Mutex
+	pending    map[string][]chan volumeZoneResult
+	batchTimer *time.Timer
+}
+
+// getZoneForVolume returns the availability zone of the given EBS volume ID.
+// It queues volumeID and, if a batch isn't already pending, starts the batch
+// window timer; any other volume IDs requested before the timer fires join
+// the same DescribeVolumes call.
+func (v *volumeZoneCache) getZoneForVolume(volumeID string) (string, error) {
+	ch := make(chan volumeZoneResult, 1)
+
+	v.mutex.Lock()
+	if v.pending == nil {
+		v.pending = map[string][]chan volumeZoneResult{}
+	}
+	v.pending[volumeID] = append(v.pending[volumeID], ch)
+	if v.batchTimer == nil {
+		window := v.batchWindow
+		if window == 0 {
+			window = defaultVolumeZoneBatchWindow
+		}
+		v.batchTimer = time.AfterFunc(window, v.fetchBatch)
+	}
+	v.mutex.Unlock()
+
+	result := <-ch
+	return result.zoneName, result.err
+}
+
+// fetchBatch drains all volume IDs queued since the last fetch and resolves
+// them with a single DescribeVolumes call.
+func (v *volumeZoneCache) fetchBatch() {
+	v.mutex.Lock()
+	volumeIDs := make([]string, 0, len(v.pending))
+	for volumeID := range v.pending {
+		volumeIDs = append(volumeIDs, volumeID)
+	}
+	pending := v.pending
+	v.pending = map[string][]chan volumeZoneResult{}
+	v.batchTimer = nil
+	v.mutex.Unlock()
+
+	zones, err := v.describeVolumeZones(volumeIDs)
+
+	for volumeID, chans := range pending {
+		result := volumeZoneResult{zoneName: zones[volumeID], err: err}
+		if err == nil && result.zoneName == "" {
+			result.err = fmt.Errorf("volume %q not found", volumeID)
+		}
+		for _, ch := range chans {
+			ch <- result
+		}
+	}
+}
+
+// describeVolumeZones returns the availability zone of each of the given EBS
+// volume IDs, in a single DescribeVolumes call.
+func (v *volumeZoneCache) describeVolumeZones(volumeIDs []string) (map[string]string, error) {
+	request := &ec2.DescribeVolumesInput{
+		VolumeIds: aws.StringSlice(volumeIDs),
+	}
+
+	volumes, err := v.cloud.ec2.DescribeVolumes(request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing volumes: %q", err)
+	}
+
+	zones := map[string]string{}
+	for _, volume := range volumes {
+		zones[aws.StringValue(volume.VolumeId)] = aws.StringValue(volume.AvailabilityZone)
+	}
+
+	return zones, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestVolumeZoneCacheBatchesDistinctVolumeIDs(t *testing.T) {
+	fake := &fakeEC2{
+		volumes: []*ec2.Volume{
+			{VolumeId: aws.String("vol-1"), AvailabilityZone: aws.String("us-east-1a")},
+			{VolumeId: aws.String("vol-2"), AvailabilityZone: aws.String("us-east-1b")},
+			{VolumeId: aws.String("vol-3"), AvailabilityZone: aws.String("us-east-1c")},
+		},
+	}
+	c := &Cloud{ec2: fake}
+	vzc := &volumeZoneCache{cloud: c, batchWindow: 50 * time.Millisecond}
+
+	volumeIDs := []string{"vol-1", "vol-2", "vol-3"}
+	results := make([]string, len(volumeIDs))
+	errs := make([]error, len(volumeIDs))
+
+	var wg sync.WaitGroup
+	wg.Add(len(volumeIDs))
+	for i, volumeID := range volumeIDs {
+		i, volumeID := i, volumeID
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = vzc.getZoneForVolume(volumeID)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("getZoneForVolume(%q) returned error: %v", volumeIDs[i], err)
+		}
+	}
+
+	want := map[string]string{"vol-1": "us-east-1a", "vol-2": "us-east-1b", "vol-3": "us-east-1c"}
+	for i, volumeID := range volumeIDs {
+		if results[i] != want[volumeID] {
+			t.Errorf("getZoneForVolume(%q) = %q, want %q", volumeID, results[i], want[volumeID])
+		}
+	}
+
+	if _, _, gotVolumes := fake.callCounts(); gotVolumes != 1 {
+		t.Errorf("DescribeVolumes called %d times, want 1 (all three distinct IDs should batch into a single call)", gotVolumes)
+	}
+}
+
+func TestVolumeZoneCacheReturnsErrorForMissingVolume(t *testing.T) {
+	fake := &fakeEC2{}
+	c := &Cloud{ec2: fake}
+	vzc := &volumeZoneCache{cloud: c, batchWindow: time.Millisecond}
+
+	if _, err := vzc.getZoneForVolume("vol-missing"); err == nil {
+		t.Error("expected error for a volume ID with no matching EC2 volume")
+	}
+}
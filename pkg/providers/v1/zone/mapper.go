@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package zone defines the contract for resolving AWS region and
+// availability zone information, so that consumers like the EBS
+// cross-region snapshot restore path don't need to depend on the full aws
+// cloud provider package.
+package zone
+
+import "context"
+
+// Mapper resolves AWS availability zones for a region, and maps a zone in
+// one region to its counterpart in another. It is implemented by the cloud
+// provider's zone cache.
+type Mapper interface {
+	// FromRegion returns the availability zone names known in region.
+	FromRegion(ctx context.Context, region string) ([]string, error)
+
+	// FromSourceRegionZone returns the availability zone in the mapper's own
+	// region that corresponds to srcZone in srcRegion, for restoring a
+	// snapshot that was copied across regions.
+	FromSourceRegionZone(ctx context.Context, srcRegion, srcZone string) (string, error)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/sjenning/cloud-provider-aws/pkg/providers/v1/zone"
+)
+
+var _ zone.Mapper = &zoneCache{}
+
+// regionZone pairs a zone name with its stable zone ID, for regions other
+// than the cache's own (zoneNameToDetails only tracks the cloud's home
+// region, so cross-region lookups can't use getZoneDetailsByNames).
+type regionZone struct {
+	name string
+	id   string
+}
+
+// describeZonesInRegion returns the zones known in the given region, name
+// and ID both, via a single DescribeAvailabilityZones call.
+func (z *zoneCache) describeZonesInRegion(region string) ([]regionZone, error) {
+	request := &ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("region-name"),
+				Values: aws.StringSlice([]string{region}),
+			},
+		},
+	}
+
+	zones, err := z.cloud.ec2.DescribeAvailabilityZones(request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing availability zones for region %s: %q", region, err)
+	}
+
+	result := make([]regionZone, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, regionZone{
+			name: aws.StringValue(zone.ZoneName),
+			id:   aws.StringValue(zone.ZoneId),
+		})
+	}
+
+	return result, nil
+}
+
+// FromRegion implements zone.Mapper, returning the availability zone names
+// known in the given region. Unlike getZoneDetailsByNames, this always hits
+// the EC2 API since the zone cache only tracks the cloud's own region.
+func (z *zoneCache) FromRegion(ctx context.Context, region string) ([]string, error) {
+	zones, err := z.describeZonesInRegion(region)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.name)
+	}
+
+	return names, nil
+}
+
+// FromSourceRegionZone implements zone.Mapper. It picks a destination
+// availability zone, in the zone cache's own region, to restore a snapshot
+// copied from srcZone in srcRegion. Zone names are account-specific aliases
+// with no cross-account or cross-region correspondence - that's exactly why
+// zone IDs exist - so we resolve srcZone to its zone ID in srcRegion and
+// match destination zones by zone-ID suffix (e.g. "az2" in "use1-az2"),
+// falling back to the first available destination zone if nothing matches.
+func (z *zoneCache) FromSourceRegionZone(ctx context.Context, srcRegion, srcZone string) (string, error) {
+	srcZones, err := z.describeZonesInRegion(srcRegion)
+	if err != nil {
+		return "", err
+	}
+
+	var srcZoneID string
+	for _, zone := range srcZones {
+		if zone.name == srcZone {
+			srcZoneID = zone.id
+			break
+		}
+	}
+	if srcZoneID == "" {
+		return "", fmt.Errorf("zone %q not found in region %s", srcZone, srcRegion)
+	}
+
+	destZones, err := z.describeZonesInRegion(z.cloud.region)
+	if err != nil {
+		return "", err
+	}
+	if len(destZones) == 0 {
+		return "", fmt.Errorf("no availability zones found in region %s", z.cloud.region)
+	}
+
+	if suffix := zoneIDSuffix(srcZoneID); suffix != "" {
+		for _, destZone := range destZones {
+			if zoneIDSuffix(destZone.id) == suffix {
+				return destZone.name, nil
+			}
+		}
+	}
+
+	return destZones[0].name, nil
+}
+
+// zoneIDSuffix returns the trailing identifier of an AWS zone ID (e.g.
+// "az2" for "use1-az2"), used to find the "same" zone across regions. Zone
+// IDs, unlike zone names, are stable identifiers rather than per-account
+// aliases, so their suffixes are meaningful to compare across regions.
+func zoneIDSuffix(zoneID string) string {
+	idx := strings.LastIndex(zoneID, "-")
+	if idx == -1 {
+		return zoneID
+	}
+	return zoneID[idx+1:]
+}
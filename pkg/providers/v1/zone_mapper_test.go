@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestZoneIDSuffix(t *testing.T) {
+	tests := []struct {
+		zoneID string
+		want   string
+	}{
+		{zoneID: "use1-az2", want: "az2"},
+		{zoneID: "usw2-lax1-az1", want: "az1"},
+		{zoneID: "noseparator", want: "noseparator"},
+		{zoneID: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zoneID, func(t *testing.T) {
+			if got := zoneIDSuffix(tt.zoneID); got != tt.want {
+				t.Errorf("zoneIDSuffix(%q) = %q, want %q", tt.zoneID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromSourceRegionZoneMatchesByZoneID(t *testing.T) {
+	fake := &fakeEC2{
+		zones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a"), ZoneId: aws.String("use1-az2")},
+			{ZoneName: aws.String("us-west-2a"), ZoneId: aws.String("usw2-az1")},
+			{ZoneName: aws.String("us-west-2b"), ZoneId: aws.String("usw2-az2")},
+		},
+	}
+	c := newCloud("us-west-2", fake)
+
+	// us-east-1a has zone ID suffix "az2", which should match us-west-2b
+	// (zone ID suffix "az2") in the destination region, not us-west-2a,
+	// even though "a" vs "b" would suggest otherwise by name alone.
+	got, err := c.zoneCache.FromSourceRegionZone(context.Background(), "us-east-1", "us-east-1a")
+	if err != nil {
+		t.Fatalf("FromSourceRegionZone returned error: %v", err)
+	}
+	if want := "us-west-2b"; got != want {
+		t.Errorf("FromSourceRegionZone() = %q, want %q", got, want)
+	}
+}
+
+func TestFromSourceRegionZoneFallsBackWhenNoSuffixMatch(t *testing.T) {
+	fake := &fakeEC2{
+		zones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a"), ZoneId: aws.String("use1-az9")},
+			{ZoneName: aws.String("us-west-2a"), ZoneId: aws.String("usw2-az1")},
+		},
+	}
+	c := newCloud("us-west-2", fake)
+
+	got, err := c.zoneCache.FromSourceRegionZone(context.Background(), "us-east-1", "us-east-1a")
+	if err != nil {
+		t.Fatalf("FromSourceRegionZone returned error: %v", err)
+	}
+	if want := "us-west-2a"; got != want {
+		t.Errorf("FromSourceRegionZone() = %q, want %q (first available)", got, want)
+	}
+}
@@ -22,19 +22,192 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	informers "k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// ZoneTypeAvailabilityZone is the zoneType of a standard AWS Availability Zone.
+	ZoneTypeAvailabilityZone = "availability-zone"
+	// ZoneTypeLocalZone is the zoneType of an AWS Local Zone.
+	ZoneTypeLocalZone = "local-zone"
+	// ZoneTypeWavelengthZone is the zoneType of an AWS Wavelength Zone.
+	ZoneTypeWavelengthZone = "wavelength-zone"
+
+	// LabelZoneType is the node label used to record the AWS zone type, so
+	// that consumers such as load balancer target selection can exclude nodes
+	// in Local Zones and Wavelength Zones, which cannot host internet-facing
+	// load balancers. It is set only for zones other than standard
+	// Availability Zones.
+	LabelZoneType = "node.kubernetes.io/zone-type"
+
+	// LabelZoneID is the node label used to record the stable AWS zone ID
+	// (e.g. use1-az2). Unlike the zone name (e.g. us-east-1a), the zone ID is
+	// consistent across accounts, which matters for cross-account EBS
+	// snapshot restore and affinity scheduling.
+	LabelZoneID = "topology.k8s.aws/zone-id"
+)
+
 type zoneDetails struct {
 	name     string
 	id       string
 	zoneType string
 }
 
+// isStandardZone reports whether z is a regular Availability Zone, as
+// opposed to a Local Zone or Wavelength Zone. AWS returns an empty zoneType
+// for older API versions/regions, which is treated as a standard AZ.
+func (z zoneDetails) isStandardZone() bool {
+	return z.zoneType == "" || z.zoneType == ZoneTypeAvailabilityZone
+}
+
 type zoneCache struct {
 	cloud             *Cloud
 	mutex             sync.Mutex
 	zoneNameToDetails map[string]zoneDetails
+
+	// nodeLister is set by SetInformers and used by ActiveZones to compute
+	// which zones currently contain Ready nodes. It is nil until the
+	// InformerUser hook has run.
+	nodeLister corelisters.NodeLister
+
+	// pendingZones queues zone names seen on a node but not yet in
+	// zoneNameToDetails, so the refreshing DescribeAvailabilityZones call
+	// happens on a worker goroutine rather than on the informer callback's
+	// hot path. It is nil until the InformerUser hook has run.
+	pendingZones workqueue.RateLimitingInterface
+}
+
+// SetInformers implements cloudprovider.InformerUser. It wires up a node
+// informer so the zone cache can track which zones currently contain nodes,
+// which ActiveZones uses to avoid dynamically provisioning volumes in zones
+// the cluster has no capacity in.
+func (c *Cloud) SetInformers(informerFactory informers.SharedInformerFactory) {
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	c.zoneCache.nodeLister = nodeInformer.Lister()
+	c.zoneCache.pendingZones = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.zoneCache.enqueueNodeZone(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.zoneCache.enqueueNodeZone(newObj)
+		},
+	})
+
+	go c.zoneCache.runZoneRefreshWorker()
+}
+
+// enqueueNodeZone queues a zone cache refresh if the node's zone isn't
+// already known. This only reads the cache under its mutex; the
+// DescribeAvailabilityZones call that actually refreshes it runs later, on
+// the worker goroutine started by SetInformers, so a slow or throttled EC2
+// call can't stall processing of subsequent informer events.
+func (z *zoneCache) enqueueNodeZone(obj interface{}) {
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return
+	}
+
+	zoneName, ok := node.Labels[v1.LabelTopologyZone]
+	if !ok || zoneName == "" {
+		return
+	}
+
+	z.mutex.Lock()
+	_, known := z.zoneNameToDetails[zoneName]
+	z.mutex.Unlock()
+	if known {
+		return
+	}
+
+	z.pendingZones.Add(zoneName)
+}
+
+// runZoneRefreshWorker processes queued zone names until pendingZones is
+// shut down. It is started as a goroutine from SetInformers.
+func (z *zoneCache) runZoneRefreshWorker() {
+	for z.processNextZoneRefresh() {
+	}
+}
+
+// processNextZoneRefresh handles a single queued zone name, refreshing the
+// zone cache for it. It reports whether the caller should keep processing.
+func (z *zoneCache) processNextZoneRefresh() bool {
+	item, shutdown := z.pendingZones.Get()
+	if shutdown {
+		return false
+	}
+	defer z.pendingZones.Done(item)
+
+	zoneName := item.(string)
+	if _, err := z.getZoneDetailsByNames([]string{zoneName}); err != nil {
+		klog.Warningf("failed to refresh zone cache for new zone %s: %v", zoneName, err)
+		z.pendingZones.AddRateLimited(item)
+		return true
+	}
+
+	z.pendingZones.Forget(item)
+	return true
+}
+
+// ActiveZones returns the set of AWS zone names that currently contain at
+// least one Ready node, as observed from the node informer cache. This is
+// the intersection of the AWS-known zones and the zones the cluster
+// actually has capacity in, so that dynamic EBS provisioning and default
+// StorageClass zone selection don't pick a zone with no nodes to attach to.
+func (z *zoneCache) ActiveZones() (sets.String, error) {
+	if z.nodeLister == nil {
+		return nil, fmt.Errorf("node informer not set, call SetInformers first")
+	}
+
+	nodes, err := z.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %q", err)
+	}
+
+	nodeZones := sets.NewString()
+	for _, node := range nodes {
+		if !nodeIsReady(node) {
+			continue
+		}
+		if zoneName, ok := node.Labels[v1.LabelTopologyZone]; ok && zoneName != "" {
+			nodeZones.Insert(zoneName)
+		}
+	}
+
+	// Validate nodeZones against the AWS-known zones so a stale or incorrect
+	// zone label on a node can't make ActiveZones claim capacity in a zone
+	// that doesn't exist; this is the actual AWS-known-zones/Ready-nodes
+	// intersection.
+	awsZoneDetails, err := z.getZoneDetailsByNames(nodeZones.List())
+	if err != nil {
+		return nil, err
+	}
+
+	active := sets.NewString()
+	for zoneName := range awsZoneDetails {
+		active.Insert(zoneName)
+	}
+
+	return active, nil
+}
+
+// nodeIsReady reports whether node has a true NodeReady condition.
+func nodeIsReady(node *v1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
 }
 
 // Get the zone details by zone names and load from the cache if available as
@@ -111,3 +284,55 @@ func (z *zoneCache) populate() error {
 
 	return nil
 }
+
+// GetZoneType returns the AWS zone type (availability-zone, local-zone, or
+// wavelength-zone) for the given zone name. Callers use this to filter out
+// non-standard zones, e.g. when selecting subnets for an ELB or deciding
+// whether to label a node as belonging to a Local Zone or Wavelength Zone.
+func (c *Cloud) GetZoneType(zoneName string) (string, error) {
+	details, err := c.zoneCache.getZoneDetailsByNames([]string{zoneName})
+	if err != nil {
+		return "", err
+	}
+
+	zone, ok := details[zoneName]
+	if !ok {
+		return "", fmt.Errorf("zone %q not found", zoneName)
+	}
+
+	return zone.zoneType, nil
+}
+
+// GetZoneIDByName returns the stable zone ID (e.g. use1-az2) for the given
+// zone name (e.g. us-east-1a). Zone name to zone ID mappings are
+// account-specific aliases assigned by AWS, so this always consults the
+// zone cache rather than deriving it from the name. If the zone was added to
+// the account after the cache was last populated, this triggers a refresh.
+func (c *Cloud) GetZoneIDByName(zoneName string) (string, error) {
+	details, err := c.zoneCache.getZoneDetailsByNames([]string{zoneName})
+	if err != nil {
+		return "", err
+	}
+
+	zone, ok := details[zoneName]
+	if !ok {
+		return "", fmt.Errorf("zone %q not found", zoneName)
+	}
+
+	return zone.id, nil
+}
+
+// zoneTopologyLabels returns the topology labels that should be applied to a
+// node running in this zone, for use by the node labeling path alongside the
+// standard topology.kubernetes.io/zone and topology.kubernetes.io/region
+// labels.
+func zoneTopologyLabels(zone zoneDetails) map[string]string {
+	labels := map[string]string{}
+	if zone.id != "" {
+		labels[LabelZoneID] = zone.id
+	}
+	if !zone.isStandardZone() {
+		labels[LabelZoneType] = zone.zoneType
+	}
+	return labels
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsStandardZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneType string
+		want     bool
+	}{
+		{name: "empty zone type is standard", zoneType: "", want: true},
+		{name: "availability-zone is standard", zoneType: ZoneTypeAvailabilityZone, want: true},
+		{name: "local-zone is not standard", zoneType: ZoneTypeLocalZone, want: false},
+		{name: "wavelength-zone is not standard", zoneType: ZoneTypeWavelengthZone, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := zoneDetails{zoneType: tt.zoneType}
+			if got := zone.isStandardZone(); got != tt.want {
+				t.Errorf("isStandardZone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZoneTopologyLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		zone zoneDetails
+		want map[string]string
+	}{
+		{
+			name: "standard zone with id only sets zone-id",
+			zone: zoneDetails{name: "us-east-1a", id: "use1-az2", zoneType: ZoneTypeAvailabilityZone},
+			want: map[string]string{LabelZoneID: "use1-az2"},
+		},
+		{
+			name: "local zone sets zone-id and zone-type",
+			zone: zoneDetails{name: "us-east-1-lax-1a", id: "usw2-lax1-az1", zoneType: ZoneTypeLocalZone},
+			want: map[string]string{LabelZoneID: "usw2-lax1-az1", LabelZoneType: ZoneTypeLocalZone},
+		},
+		{
+			name: "no id and standard type produces no labels",
+			zone: zoneDetails{name: "us-east-1a"},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zoneTopologyLabels(tt.zone); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("zoneTopologyLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveZonesRequiresInformer(t *testing.T) {
+	z := &zoneCache{}
+	if _, err := z.ActiveZones(); err == nil {
+		t.Fatal("expected error when SetInformers has not been called")
+	}
+}